@@ -0,0 +1,121 @@
+package translator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"strings"
+)
+
+const deepLAPIURL = "https://api-free.deepl.com/v2/translate"
+
+type deepLTranslator struct {
+	*basicTranslator
+	apiKey string
+}
+
+// NewDeepL creates a Translator backed by the DeepL API. apiKey may be
+// empty at construction time, in which case it's hydrated from the API key
+// a previous run persisted to the session cache (see Session/Credential);
+// most deployments will still pass it directly.
+// Unlike the other engines in this package, DeepL reports the language it
+// detected back to us, so Detect is fully implemented rather than falling
+// back to basicTranslator's ErrNotImplemented default.
+// opts can attach middleware via WithMiddleware (see also Use for
+// middleware shared across all engines).
+func NewDeepL(cache SessionCache, apiKey string, opts ...Option) Translator {
+	o := resolveOptions(opts...)
+	apiKey, _ = hydrateCredential(cache, EngineDeepL, apiKey, "")
+	t := &deepLTranslator{
+		basicTranslator: &basicTranslator{
+			engine:     EngineDeepL,
+			agent:      DefaultAgent,
+			cache:      cache,
+			middleware: o.middleware,
+		},
+		apiKey: apiKey,
+	}
+	t.basicTranslator.Translator = t
+	return t
+}
+
+func (d *deepLTranslator) Session() (*Session, error) {
+	return &Session{Credential: &Credential{APIKey: d.apiKey}}, nil
+}
+
+type deepLResponse struct {
+	Translations []struct {
+		Text                   string `json:"text"`
+		DetectedSourceLanguage string `json:"detected_source_language"`
+	} `json:"translations"`
+}
+
+// Detect asks DeepL to identify text's language by translating it without a
+// source_lang and reading back detected_source_language. DeepL doesn't
+// report a confidence score, so confidence is always 1 on success.
+func (d *deepLTranslator) Detect(text string) (lang string, confidence float64, err error) {
+	if d.apiKey == "" {
+		return "", 0, errors.New("deepl: api key is required")
+	}
+	data := url.Values{}
+	data.Set("auth_key", d.apiKey)
+	data.Set("text", text)
+	data.Set("target_lang", "EN")
+	resp, err := d.postForm(context.Background(), deepLAPIURL, data)
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Body.Close()
+	if err := d.checkStatus(resp); err != nil {
+		return "", 0, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, err
+	}
+	var r deepLResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return "", 0, err
+	}
+	if len(r.Translations) == 0 || r.Translations[0].DetectedSourceLanguage == "" {
+		return "", 0, errors.New("deepl: empty response")
+	}
+	return strings.ToLower(r.Translations[0].DetectedSourceLanguage), 1, nil
+}
+
+func (d *deepLTranslator) translateRaw(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	if d.apiKey == "" {
+		return nil, errors.New("deepl: api key is required")
+	}
+	sl, tl, err := d.keepLang(srcLang, targetLang, text)
+	if err != nil {
+		return nil, err
+	}
+	data := url.Values{}
+	data.Set("auth_key", d.apiKey)
+	data.Set("text", text)
+	data.Set("source_lang", strings.ToUpper(sl))
+	data.Set("target_lang", strings.ToUpper(tl))
+	resp, err := d.postForm(ctx, deepLAPIURL, data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := d.checkStatus(resp); err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var r deepLResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	if len(r.Translations) == 0 {
+		return nil, errors.New("deepl: empty response")
+	}
+	return TextResult(r.Translations[0].Text), nil
+}
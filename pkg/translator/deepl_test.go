@@ -0,0 +1,61 @@
+package translator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeepLTranslateRaw(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm: %v", err)
+		}
+		if got := r.FormValue("auth_key"); got != "test-key" {
+			t.Errorf("auth_key = %q, want %q", got, "test-key")
+		}
+		if got := r.FormValue("source_lang"); got != "EN" {
+			t.Errorf("source_lang = %q, want %q", got, "EN")
+		}
+		fmt.Fprint(w, `{"translations":[{"text":"Bonjour"}]}`)
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	d := NewDeepL(NewSessionCache(MemSessionStore{}), "test-key")
+	r, err := d.Translate("en", "fr", "hello")
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if r.String() != "Bonjour" {
+		t.Fatalf("got %q, want %q", r.String(), "Bonjour")
+	}
+}
+
+func TestDeepLTranslateRequiresAPIKey(t *testing.T) {
+	d := NewDeepL(NewSessionCache(MemSessionStore{}), "")
+	if _, err := d.Translate("en", "fr", "hello"); err == nil {
+		t.Fatal("expected an error when no API key is configured")
+	}
+}
+
+func TestDeepLDetect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"translations":[{"text":"Bonjour","detected_source_language":"EN"}]}`)
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	d := NewDeepL(NewSessionCache(MemSessionStore{}), "test-key")
+	lang, confidence, err := d.Detect("hello")
+	if err != nil {
+		t.Fatalf("Detect returned error: %v", err)
+	}
+	if lang != "en" {
+		t.Errorf("lang = %q, want %q", lang, "en")
+	}
+	if confidence != 1 {
+		t.Errorf("confidence = %v, want 1", confidence)
+	}
+}
@@ -0,0 +1,92 @@
+package translator
+
+import "testing"
+
+// detectingFakeEngine is a fakeEngine that also overrides Detect, so tests
+// can check that keepLang dispatches to it for an empty/"auto" srcLang.
+type detectingFakeEngine struct {
+	*fakeEngine
+	detectedLang string
+	detectCalls  int
+}
+
+func newDetectingFakeEngine(name EngineName, detectedLang string) *detectingFakeEngine {
+	f := &detectingFakeEngine{
+		fakeEngine:   newFakeEngine(name, "", nil, 0),
+		detectedLang: detectedLang,
+	}
+	f.fakeEngine.basicTranslator.Translator = f
+	return f
+}
+
+func (f *detectingFakeEngine) Detect(text string) (string, float64, error) {
+	f.detectCalls++
+	return f.detectedLang, 1, nil
+}
+
+func TestKeepLangDispatchesToDetectForEmptySrcLang(t *testing.T) {
+	f := newDetectingFakeEngine("fake-detect-empty", "en")
+
+	sl, tl, err := f.basicTranslator.keepLang("", "fr", "hello")
+	if err != nil {
+		t.Fatalf("keepLang returned error: %v", err)
+	}
+	if sl != "en" || tl != "fr" {
+		t.Fatalf("got (%q, %q), want (%q, %q)", sl, tl, "en", "fr")
+	}
+	if f.detectCalls != 1 {
+		t.Fatalf("Detect was called %d times, want 1", f.detectCalls)
+	}
+}
+
+func TestKeepLangDispatchesToDetectForAutoSrcLang(t *testing.T) {
+	f := newDetectingFakeEngine("fake-detect-auto", "en")
+
+	if _, _, err := f.basicTranslator.keepLang("auto", "fr", "hello"); err != nil {
+		t.Fatalf("keepLang returned error: %v", err)
+	}
+	if f.detectCalls != 1 {
+		t.Fatalf("Detect was called %d times, want 1", f.detectCalls)
+	}
+}
+
+func TestKeepLangSkipsDetectForExplicitSrcLang(t *testing.T) {
+	f := newDetectingFakeEngine("fake-detect-explicit", "en")
+
+	sl, _, err := f.basicTranslator.keepLang("de", "fr", "hallo")
+	if err != nil {
+		t.Fatalf("keepLang returned error: %v", err)
+	}
+	if sl != "de" {
+		t.Fatalf("got %q, want %q", sl, "de")
+	}
+	if f.detectCalls != 0 {
+		t.Fatalf("Detect was called %d times, want 0 when srcLang is explicit", f.detectCalls)
+	}
+}
+
+func TestKeepLangReturnsErrNotImplementedWithoutTranslator(t *testing.T) {
+	f := newFakeEngine("fake-detect-nildetect", "", nil, 0)
+	f.basicTranslator.Translator = nil
+
+	if _, _, err := f.basicTranslator.keepLang("", "fr", "hello"); err != ErrNotImplemented {
+		t.Fatalf("got %v, want ErrNotImplemented when no Translator is wired for Detect", err)
+	}
+}
+
+func TestTopLevelDetectMirrorsTrans(t *testing.T) {
+	f := newDetectingFakeEngine("fake-detect-toplevel", "ja")
+	RegisterTranslator(f)
+
+	lang, _, err := Detect("fake-detect-toplevel", "hello")
+	if err != nil {
+		t.Fatalf("Detect returned error: %v", err)
+	}
+	if lang != "ja" {
+		t.Fatalf("got %q, want %q", lang, "ja")
+	}
+
+	if _, _, err := Detect("no-such-engine", "hello"); err == nil {
+		t.Fatal("expected an error for an unregistered engine")
+	}
+}
@@ -0,0 +1,37 @@
+package translator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// redirectTransport rewrites every outgoing request to target's host,
+// letting tests exercise an engine's real translateRaw/Detect against an
+// httptest.Server even though the engine's API URL is a hardcoded const.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (r *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = r.target.Scheme
+	req.URL.Host = r.target.Host
+	req.Host = r.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// withTestServer points sharedHTTPClient at ts for the duration of the
+// test, restoring the original transport on cleanup.
+func withTestServer(t *testing.T, ts *httptest.Server) {
+	t.Helper()
+	target, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	orig := sharedHTTPClient.Transport
+	sharedHTTPClient.Transport = &redirectTransport{target: target}
+	t.Cleanup(func() {
+		sharedHTTPClient.Transport = orig
+	})
+}
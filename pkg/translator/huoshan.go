@@ -0,0 +1,130 @@
+package translator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+)
+
+const huoShanAPIURL = "https://translate.volcengine.com/crx/translate/v1"
+
+type huoShanTranslator struct {
+	*basicTranslator
+}
+
+// NewHuoShan creates a Translator backed by Volcano translation. opts can
+// attach middleware via WithMiddleware (see also Use for middleware shared
+// across all engines).
+func NewHuoShan(cache SessionCache, opts ...Option) Translator {
+	o := resolveOptions(opts...)
+	t := &huoShanTranslator{
+		basicTranslator: &basicTranslator{
+			engine:     EngineHuoShan,
+			agent:      DefaultAgent,
+			cache:      cache,
+			middleware: o.middleware,
+		},
+	}
+	t.basicTranslator.Translator = t
+	return t
+}
+
+func (h *huoShanTranslator) Session() (*Session, error) {
+	return &Session{}, nil
+}
+
+type huoShanRequest struct {
+	Source   string `json:"source_language"`
+	Target   string `json:"target_language"`
+	Text     string `json:"text"`
+	TextType string `json:"text_type"`
+}
+
+type huoShanSense struct {
+	POS         string `json:"pos"`
+	Translation string `json:"translation"`
+	Example     string `json:"example"`
+}
+
+type huoShanResponse struct {
+	TranslatedText string         `json:"translation"`
+	Senses         []huoShanSense `json:"dict,omitempty"`
+}
+
+// translateRaw returns a *DictResult for single-word lookups (so callers
+// that care about part-of-speech/senses can type-assert) and a TextResult
+// for everything else. Both satisfy Result, so callers that only want the
+// string keep working unchanged.
+func (h *huoShanTranslator) translateRaw(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	if isSingleWord(text) {
+		return h.dictCtx(ctx, srcLang, targetLang, text)
+	}
+	resp, err := h.doTranslate(ctx, srcLang, targetLang, text)
+	if err != nil {
+		return nil, err
+	}
+	return TextResult(resp.TranslatedText), nil
+}
+
+func (h *huoShanTranslator) Dict(srcLang, targetLang, word string) (*DictResult, error) {
+	return h.dictCtx(context.Background(), srcLang, targetLang, word)
+}
+
+func (h *huoShanTranslator) dictCtx(ctx context.Context, srcLang, targetLang, word string) (*DictResult, error) {
+	resp, err := h.doTranslate(ctx, srcLang, targetLang, word)
+	if err != nil {
+		return nil, err
+	}
+	d := &DictResult{Text: resp.TranslatedText}
+	for _, s := range resp.Senses {
+		d.Details = append(d.Details, Explanation{
+			POS:         s.POS,
+			Translation: s.Translation,
+			Example:     s.Example,
+		})
+	}
+	return d, nil
+}
+
+func (h *huoShanTranslator) doTranslate(ctx context.Context, srcLang, targetLang, text string) (*huoShanResponse, error) {
+	sl, tl, err := h.keepLang(srcLang, targetLang, text)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(huoShanRequest{
+		Source:   sl,
+		Target:   tl,
+		Text:     text,
+		TextType: "plain",
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.post(ctx, huoShanAPIURL, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := h.checkStatus(resp); err != nil {
+		return nil, err
+	}
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var hr huoShanResponse
+	if err := json.Unmarshal(raw, &hr); err != nil {
+		return nil, err
+	}
+	if hr.TranslatedText == "" {
+		return nil, errors.New("huoshan: empty response")
+	}
+	return &hr, nil
+}
+
+func isSingleWord(text string) bool {
+	text = strings.TrimSpace(text)
+	return text != "" && !strings.ContainsAny(text, " \t\n")
+}
@@ -0,0 +1,84 @@
+package translator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsSingleWord(t *testing.T) {
+	cases := map[string]bool{
+		"hello":        true,
+		"  hello  ":    true,
+		"hello world":  false,
+		"hello\tworld": false,
+		"hello\nworld": false,
+		"":             false,
+		"   ":          false,
+		"你好":           true,
+	}
+	for text, want := range cases {
+		if got := isSingleWord(text); got != want {
+			t.Errorf("isSingleWord(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestHuoShanTranslateRawSentence(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"translation":"Bonjour tout le monde"}`)
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	h := NewHuoShan(NewSessionCache(MemSessionStore{}))
+	r, err := h.Translate("en", "fr", "hello world")
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if r.String() != "Bonjour tout le monde" {
+		t.Fatalf("got %q, want %q", r.String(), "Bonjour tout le monde")
+	}
+	if _, ok := r.(*DictResult); ok {
+		t.Fatal("multi-word input should return a TextResult, not a DictResult")
+	}
+}
+
+func TestHuoShanTranslateRawSingleWordReturnsDictResult(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"translation":"hello","dict":[{"pos":"int.","translation":"hello","example":"Hola, ¿cómo estás?"}]}`)
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	h := NewHuoShan(NewSessionCache(MemSessionStore{}))
+	r, err := h.Translate("es", "en", "hola")
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	d, ok := r.(*DictResult)
+	if !ok {
+		t.Fatalf("got %T, want *DictResult for a single-word lookup", r)
+	}
+	if len(d.Details) != 1 || d.Details[0].POS != "int." {
+		t.Fatalf("got %+v, want one Explanation with POS %q", d, "int.")
+	}
+}
+
+func TestHuoShanDict(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"translation":"hello","dict":[{"pos":"int.","translation":"hello"}]}`)
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	h := NewHuoShan(NewSessionCache(MemSessionStore{}))
+	d, err := h.(DictTranslator).Dict("es", "en", "hola")
+	if err != nil {
+		t.Fatalf("Dict returned error: %v", err)
+	}
+	if d.String() != "hello" {
+		t.Fatalf("got %q, want %q", d.String(), "hello")
+	}
+}
@@ -0,0 +1,88 @@
+package translator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/url"
+)
+
+const defaultLibreTranslateEndpoint = "https://libretranslate.com"
+
+type libreTranslateTranslator struct {
+	*basicTranslator
+	endpoint string
+	apiKey   string
+}
+
+// NewLibreTranslate creates a Translator backed by a LibreTranslate
+// instance. LibreTranslate is commonly self-hosted, so WithEndpoint and
+// WithAPIKey are both useful here; whichever of the two is not given is
+// hydrated from what a previous run persisted to the session cache (see
+// Session/Credential), and the public libretranslate.com service is used
+// as a last resort for the endpoint.
+func NewLibreTranslate(cache SessionCache, opts ...Option) Translator {
+	o := resolveOptions(opts...)
+	apiKey, endpoint := hydrateCredential(cache, EngineLibreTranslate, o.apiKey, o.endpoint)
+	o.apiKey = apiKey
+	if endpoint == "" {
+		endpoint = defaultLibreTranslateEndpoint
+	}
+	t := &libreTranslateTranslator{
+		basicTranslator: &basicTranslator{
+			engine:     EngineLibreTranslate,
+			agent:      DefaultAgent,
+			cache:      cache,
+			middleware: o.middleware,
+		},
+		endpoint: endpoint,
+		apiKey:   o.apiKey,
+	}
+	t.basicTranslator.Translator = t
+	return t
+}
+
+func (l *libreTranslateTranslator) Session() (*Session, error) {
+	return &Session{Credential: &Credential{APIKey: l.apiKey, URL: l.endpoint}}, nil
+}
+
+type libreTranslateResponse struct {
+	TranslatedText string `json:"translatedText"`
+	Error          string `json:"error"`
+}
+
+func (l *libreTranslateTranslator) translateRaw(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	sl, tl, err := l.keepLang(srcLang, targetLang, text)
+	if err != nil {
+		return nil, err
+	}
+	data := url.Values{}
+	data.Set("q", text)
+	data.Set("source", sl)
+	data.Set("target", tl)
+	data.Set("format", "text")
+	if l.apiKey != "" {
+		data.Set("api_key", l.apiKey)
+	}
+	resp, err := l.postForm(ctx, l.endpoint+"/translate", data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := l.checkStatus(resp); err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var r libreTranslateResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	if r.Error != "" {
+		return nil, errors.New("libretranslate: " + r.Error)
+	}
+	return TextResult(r.TranslatedText), nil
+}
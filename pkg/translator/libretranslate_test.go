@@ -0,0 +1,54 @@
+package translator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLibreTranslateTranslateRaw(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm: %v", err)
+		}
+		if got := r.FormValue("api_key"); got != "test-key" {
+			t.Errorf("api_key = %q, want %q", got, "test-key")
+		}
+		if got := r.FormValue("q"); got != "hello" {
+			t.Errorf("q = %q, want %q", got, "hello")
+		}
+		fmt.Fprint(w, `{"translatedText":"Bonjour"}`)
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	l := NewLibreTranslate(NewSessionCache(MemSessionStore{}), WithEndpoint(ts.URL), WithAPIKey("test-key"))
+	r, err := l.Translate("en", "fr", "hello")
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if r.String() != "Bonjour" {
+		t.Fatalf("got %q, want %q", r.String(), "Bonjour")
+	}
+}
+
+func TestLibreTranslateTranslateRawReturnsServerError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"error":"invalid target language"}`)
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	l := NewLibreTranslate(NewSessionCache(MemSessionStore{}), WithEndpoint(ts.URL))
+	if _, err := l.Translate("en", "zz", "hello"); err == nil {
+		t.Fatal("expected an error when the server reports one")
+	}
+}
+
+func TestLibreTranslateDefaultsToPublicEndpoint(t *testing.T) {
+	l := NewLibreTranslate(NewSessionCache(MemSessionStore{})).(*libreTranslateTranslator)
+	if l.endpoint != defaultLibreTranslateEndpoint {
+		t.Fatalf("endpoint = %q, want the public default %q", l.endpoint, defaultLibreTranslateEndpoint)
+	}
+}
@@ -0,0 +1,208 @@
+package translator
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+func cacheKey(engine EngineName, srcLang, targetLang, text string) string {
+	h := sha256.Sum256([]byte(text))
+	return string(engine) + "|" + srcLang + "|" + targetLang + "|" + hex.EncodeToString(h[:])
+}
+
+type cacheEntry struct {
+	key       string
+	result    Result
+	expiresAt time.Time
+}
+
+// resultCache is a small LRU keyed on (engine, srcLang, targetLang,
+// sha256(text)) with per-entry TTL expiry.
+type resultCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+func newResultCache(capacity int, ttl time.Duration) *resultCache {
+	return &resultCache{
+		capacity: capacity,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *resultCache) get(key string) (Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return entry.result, true
+}
+
+func (c *resultCache) set(key string, result Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value.(*cacheEntry).result = result
+		el.Value.(*cacheEntry).expiresAt = time.Now().Add(c.ttl)
+		c.ll.MoveToFront(el)
+		return
+	}
+	el := c.ll.PushFront(&cacheEntry{key: key, result: result, expiresAt: time.Now().Add(c.ttl)})
+	c.items[key] = el
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*cacheEntry).key)
+		}
+	}
+}
+
+// CacheMiddleware caches successful results for ttl, keyed on
+// (engine, srcLang, targetLang, sha256(text)), evicting least-recently-used
+// entries once capacity is exceeded.
+func CacheMiddleware(capacity int, ttl time.Duration) Middleware {
+	c := newResultCache(capacity, ttl)
+	return func(next TranslateFunc) TranslateFunc {
+		return func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+			key := cacheKey(engine, srcLang, targetLang, text)
+			if r, ok := c.get(key); ok {
+				return r, nil
+			}
+			r, err := next(ctx, engine, srcLang, targetLang, text)
+			if err != nil {
+				return nil, err
+			}
+			c.set(key, r)
+			return r, nil
+		}
+	}
+}
+
+// tokenBucket is a minimal token-bucket rate limiter.
+type tokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	ratePerSec float64
+	burst      float64
+	last       time.Time
+}
+
+func newTokenBucket(ratePerSec float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		tokens:     float64(burst),
+		ratePerSec: ratePerSec,
+		burst:      float64(burst),
+		last:       time.Now(),
+	}
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.ratePerSec
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.ratePerSec * float64(time.Second))
+		b.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// RateLimitMiddleware throttles requests to ratePerSec with a burst
+// allowance, to avoid tripping an engine's IP ban threshold. A single
+// RateLimitMiddleware shares one bucket across every engine it's attached
+// to, so construct one per engine for independent limits.
+func RateLimitMiddleware(ratePerSec float64, burst int) Middleware {
+	tb := newTokenBucket(ratePerSec, burst)
+	return func(next TranslateFunc) TranslateFunc {
+		return func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+			if err := tb.wait(ctx); err != nil {
+				return nil, err
+			}
+			return next(ctx, engine, srcLang, targetLang, text)
+		}
+	}
+}
+
+// RetryMiddleware retries on 5xx/429 StatusError responses with
+// exponential backoff and jitter, up to maxRetries additional attempts.
+func RetryMiddleware(maxRetries int, baseDelay time.Duration) Middleware {
+	return func(next TranslateFunc) TranslateFunc {
+		return func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+			var r Result
+			var err error
+			for attempt := 0; attempt <= maxRetries; attempt++ {
+				r, err = next(ctx, engine, srcLang, targetLang, text)
+				if err == nil || !isRetryable(err) || attempt == maxRetries {
+					return r, err
+				}
+				delay := baseDelay << uint(attempt)
+				delay += time.Duration(rand.Int63n(int64(baseDelay)))
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(delay):
+				}
+			}
+			return r, err
+		}
+	}
+}
+
+func isRetryable(err error) bool {
+	se, ok := err.(*StatusError)
+	return ok && (se.StatusCode == 429 || se.StatusCode >= 500)
+}
+
+// LoggingMiddleware logs each request and its outcome through logger (nil
+// defaults to log.Default()).
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(next TranslateFunc) TranslateFunc {
+		return func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+			start := time.Now()
+			r, err := next(ctx, engine, srcLang, targetLang, text)
+			if err != nil {
+				logger.Printf("translate engine=%s %s->%s chars=%d took=%s error=%v", engine, srcLang, targetLang, len(text), time.Since(start), err)
+			} else {
+				logger.Printf("translate engine=%s %s->%s chars=%d took=%s ok", engine, srcLang, targetLang, len(text), time.Since(start))
+			}
+			return r, err
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimitMiddlewareThrottlesBeyondBurst(t *testing.T) {
+	mw := RateLimitMiddleware(10, 1) // 1 burst, 10/s => 100ms between extra calls
+	calls := 0
+	fn := mw(func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+		calls++
+		return TextResult("ok"), nil
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := fn(context.Background(), EngineDeepL, "en", "fr", "hi"); err != nil {
+			t.Fatalf("call %d returned error: %v", i, err)
+		}
+	}
+	elapsed := time.Since(start)
+	if calls != 3 {
+		t.Fatalf("got %d calls, want 3", calls)
+	}
+	// The burst covers the first call; the next two must each wait ~100ms.
+	if elapsed < 150*time.Millisecond {
+		t.Fatalf("elapsed %v, expected rate limiting to slow the 2nd/3rd calls", elapsed)
+	}
+}
+
+func TestRateLimitMiddlewareRespectsContextCancellation(t *testing.T) {
+	mw := RateLimitMiddleware(1, 1) // first call consumes the only token
+	fn := mw(func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+		return TextResult("ok"), nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := fn(ctx, EngineDeepL, "en", "fr", "hi"); err != nil {
+		t.Fatalf("first call returned error: %v", err)
+	}
+	cancel()
+	if _, err := fn(ctx, EngineDeepL, "en", "fr", "hi"); err == nil {
+		t.Fatal("expected the second call to fail once ctx is cancelled while waiting for a token")
+	}
+}
+
+func TestRetryMiddlewareRetriesRetryableErrors(t *testing.T) {
+	mw := RetryMiddleware(2, time.Millisecond)
+	attempts := 0
+	fn := mw(func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, &StatusError{StatusCode: 503}
+		}
+		return TextResult("ok"), nil
+	})
+
+	r, err := fn(context.Background(), EngineDeepL, "en", "fr", "hi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.String() != "ok" {
+		t.Fatalf("got %v, want %q", r, "ok")
+	}
+	if attempts != 3 {
+		t.Fatalf("got %d attempts, want 3 (1 initial + 2 retries)", attempts)
+	}
+}
+
+func TestRetryMiddlewareGivesUpAfterMaxRetries(t *testing.T) {
+	mw := RetryMiddleware(2, time.Millisecond)
+	attempts := 0
+	fn := mw(func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+		attempts++
+		return nil, &StatusError{StatusCode: 500}
+	})
+
+	_, err := fn(context.Background(), EngineDeepL, "en", "fr", "hi")
+	if err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+	if attempts != 3 {
+		t.Fatalf("got %d attempts, want 3 (1 initial + 2 retries)", attempts)
+	}
+}
+
+func TestRetryMiddlewareDoesNotRetryNonRetryableErrors(t *testing.T) {
+	mw := RetryMiddleware(2, time.Millisecond)
+	attempts := 0
+	wantErr := errors.New("bad request")
+	fn := mw(func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+		attempts++
+		return nil, wantErr
+	})
+
+	_, err := fn(context.Background(), EngineDeepL, "en", "fr", "hi")
+	if err != wantErr {
+		t.Fatalf("got error %v, want %v", err, wantErr)
+	}
+	if attempts != 1 {
+		t.Fatalf("got %d attempts, want 1 (non-retryable errors must not be retried)", attempts)
+	}
+}
@@ -0,0 +1,66 @@
+package translator
+
+// engineOptions accumulates the settings every NewXxx constructor in this
+// package can take as trailing, optional Options.
+type engineOptions struct {
+	middleware []Middleware
+	endpoint   string
+	apiKey     string
+}
+
+// Option configures optional, construction-time settings shared across
+// every NewXxx constructor in this package.
+type Option func(*engineOptions)
+
+// WithMiddleware attaches middleware that applies to this engine only (see
+// also Use for middleware shared across all engines).
+func WithMiddleware(mws ...Middleware) Option {
+	return func(o *engineOptions) {
+		o.middleware = append(o.middleware, mws...)
+	}
+}
+
+// WithEndpoint points a self-hostable engine (currently LibreTranslate) at
+// an instance other than its public default.
+func WithEndpoint(endpoint string) Option {
+	return func(o *engineOptions) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithAPIKey sets the API key sent with every request, for engines whose
+// key is optional at construction time rather than a required argument.
+func WithAPIKey(key string) Option {
+	return func(o *engineOptions) {
+		o.apiKey = key
+	}
+}
+
+func resolveOptions(opts ...Option) engineOptions {
+	var o engineOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// hydrateCredential fills in apiKey/endpoint from engine's persisted
+// Session.Credential when the caller didn't supply them explicitly, so a
+// key configured in a previous process run survives a restart. Explicit
+// constructor arguments and options always win over the persisted value.
+func hydrateCredential(cache SessionCache, engine EngineName, apiKey, endpoint string) (string, string) {
+	if apiKey != "" && endpoint != "" {
+		return apiKey, endpoint
+	}
+	s, err := cache.LoadPersisted(engine)
+	if err != nil || s == nil || s.Credential == nil {
+		return apiKey, endpoint
+	}
+	if apiKey == "" {
+		apiKey = s.Credential.APIKey
+	}
+	if endpoint == "" {
+		endpoint = s.Credential.URL
+	}
+	return apiKey, endpoint
+}
@@ -0,0 +1,71 @@
+package translator
+
+import "testing"
+
+// recordingStore is a SessionStore that behaves like FileSessionStore
+// across a process restart: Save followed by a fresh cache's Load (or
+// LoadPersisted) round-trips whatever was last saved.
+type recordingStore struct {
+	saved map[EngineName]*Session
+}
+
+func newRecordingStore() *recordingStore {
+	return &recordingStore{saved: make(map[EngineName]*Session)}
+}
+
+func (s *recordingStore) Load(engine EngineName) (*Session, error) {
+	sess, ok := s.saved[engine]
+	if !ok {
+		return nil, errNotFoundForTest
+	}
+	return sess, nil
+}
+
+func (s *recordingStore) Save(engine EngineName, session *Session) error {
+	s.saved[engine] = session
+	return nil
+}
+
+var errNotFoundForTest = &StatusError{StatusCode: 404}
+
+func TestHydrateCredentialPrefersExplicitValues(t *testing.T) {
+	store := newRecordingStore()
+	store.saved[EngineDeepL] = &Session{Credential: &Credential{APIKey: "persisted"}}
+	cache := NewSessionCache(store)
+
+	apiKey, _ := hydrateCredential(cache, EngineDeepL, "explicit", "")
+	if apiKey != "explicit" {
+		t.Fatalf("got %q, want explicit value to win over persisted credential", apiKey)
+	}
+}
+
+func TestHydrateCredentialFallsBackToPersisted(t *testing.T) {
+	store := newRecordingStore()
+	store.saved[EngineWatson] = &Session{Credential: &Credential{APIKey: "saved-key", URL: "saved-url"}}
+	cache := NewSessionCache(store)
+
+	apiKey, endpoint := hydrateCredential(cache, EngineWatson, "", "")
+	if apiKey != "saved-key" || endpoint != "saved-url" {
+		t.Fatalf("got (%q, %q), want (%q, %q)", apiKey, endpoint, "saved-key", "saved-url")
+	}
+}
+
+func TestHydrateCredentialTreatsMissingSessionAsEmpty(t *testing.T) {
+	cache := NewSessionCache(newRecordingStore())
+
+	apiKey, endpoint := hydrateCredential(cache, EngineReverso, "", "")
+	if apiKey != "" || endpoint != "" {
+		t.Fatalf("got (%q, %q), want both empty when nothing was ever persisted", apiKey, endpoint)
+	}
+}
+
+func TestNewDeepLHydratesAPIKeyFromPersistedSession(t *testing.T) {
+	store := newRecordingStore()
+	store.saved[EngineDeepL] = &Session{Credential: &Credential{APIKey: "restarted-key"}}
+	cache := NewSessionCache(store)
+
+	d := NewDeepL(cache, "").(*deepLTranslator)
+	if d.apiKey != "restarted-key" {
+		t.Fatalf("got apiKey %q, want it hydrated from the persisted session", d.apiKey)
+	}
+}
@@ -0,0 +1,146 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Strategy controls how Pool combines the results of querying multiple
+// engines for the same translation.
+type Strategy int
+
+const (
+	// FirstSuccess returns as soon as one engine succeeds, cancelling the
+	// remaining in-flight requests.
+	FirstSuccess Strategy = iota
+	// All waits for every engine and returns a map of the successful
+	// results keyed by engine; engines that errored are omitted.
+	All
+	// Quorum waits for every engine and returns the translation that the
+	// largest number of engines agree on (after trimming/case-folding).
+	Quorum
+)
+
+// Pool fans a single Translate call out across multiple engines
+// concurrently. Timeout, if non-zero, bounds each individual engine call.
+type Pool struct {
+	Engines  []EngineName
+	Strategy Strategy
+	Timeout  time.Duration
+}
+
+// NewPool creates a Pool that queries engines concurrently using strategy.
+// A zero timeout means engine calls are only bounded by the ctx passed to
+// Run.
+func NewPool(strategy Strategy, timeout time.Duration, engines ...EngineName) *Pool {
+	return &Pool{Engines: engines, Strategy: strategy, Timeout: timeout}
+}
+
+func (p *Pool) translateOne(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+	t, ok := ENGINES[engine]
+	if !ok {
+		return nil, errors.New("pool: unknown engine " + string(engine))
+	}
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+	return t.TranslateCtx(ctx, srcLang, targetLang, text)
+}
+
+// Run executes the pool's Strategy and returns a value whose concrete type
+// depends on it: Result for FirstSuccess, map[EngineName]Result for All,
+// and string for Quorum.
+func (p *Pool) Run(ctx context.Context, srcLang, targetLang, text string) (interface{}, error) {
+	switch p.Strategy {
+	case FirstSuccess:
+		return p.firstSuccess(ctx, srcLang, targetLang, text)
+	case All:
+		return p.all(ctx, srcLang, targetLang, text), nil
+	case Quorum:
+		return p.quorum(ctx, srcLang, targetLang, text)
+	default:
+		return nil, errors.New("pool: unknown strategy")
+	}
+}
+
+func (p *Pool) firstSuccess(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	if len(p.Engines) == 0 {
+		return nil, errors.New("pool: no engines configured")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		r   Result
+		err error
+	}
+	ch := make(chan outcome, len(p.Engines))
+	for _, e := range p.Engines {
+		e := e
+		go func() {
+			r, err := p.translateOne(ctx, e, srcLang, targetLang, text)
+			ch <- outcome{r, err}
+		}()
+	}
+
+	var lastErr error
+	for range p.Engines {
+		o := <-ch
+		if o.err == nil {
+			return o.r, nil
+		}
+		lastErr = o.err
+	}
+	return nil, lastErr
+}
+
+// all queries every engine concurrently, keyed by engine. Engines that
+// errored are simply absent from the returned map.
+func (p *Pool) all(ctx context.Context, srcLang, targetLang, text string) map[EngineName]Result {
+	results := make(map[EngineName]Result, len(p.Engines))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, e := range p.Engines {
+		wg.Add(1)
+		go func(e EngineName) {
+			defer wg.Done()
+			r, err := p.translateOne(ctx, e, srcLang, targetLang, text)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			results[e] = r
+			mu.Unlock()
+		}(e)
+	}
+	wg.Wait()
+	return results
+}
+
+func (p *Pool) quorum(ctx context.Context, srcLang, targetLang, text string) (string, error) {
+	results := p.all(ctx, srcLang, targetLang, text)
+	votes := make(map[string]int)
+	best, bestCount := "", 0
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		key := strings.TrimSpace(strings.ToLower(r.String()))
+		if key == "" {
+			continue
+		}
+		votes[key]++
+		if votes[key] > bestCount {
+			best, bestCount = r.String(), votes[key]
+		}
+	}
+	if bestCount == 0 {
+		return "", errors.New("pool: no engine returned a usable translation")
+	}
+	return best, nil
+}
@@ -0,0 +1,110 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeEngine struct {
+	*basicTranslator
+	result string
+	err    error
+	delay  time.Duration
+}
+
+func newFakeEngine(name EngineName, result string, err error, delay time.Duration) *fakeEngine {
+	t := &fakeEngine{
+		basicTranslator: &basicTranslator{
+			engine: name,
+			agent:  DefaultAgent,
+			cache:  NewSessionCache(MemSessionStore{}),
+		},
+		result: result,
+		err:    err,
+		delay:  delay,
+	}
+	t.basicTranslator.Translator = t
+	return t
+}
+
+func (f *fakeEngine) Session() (*Session, error) {
+	return &Session{}, nil
+}
+
+func (f *fakeEngine) translateRaw(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return TextResult(f.result), nil
+}
+
+func TestPoolQuorumPicksMajority(t *testing.T) {
+	a, b, c := EngineName("fake-a"), EngineName("fake-b"), EngineName("fake-c")
+	RegisterTranslator(newFakeEngine(a, "Hello", nil, 0))
+	RegisterTranslator(newFakeEngine(b, "Hello", nil, 0))
+	RegisterTranslator(newFakeEngine(c, "Hi", nil, 0))
+
+	p := NewPool(Quorum, 0, a, b, c)
+	got, err := p.Run(context.Background(), "en", "fr", "hi")
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if got != "Hello" {
+		t.Fatalf("got %q, want %q", got, "Hello")
+	}
+}
+
+func TestPoolQuorumNoConsensusWhenAllFail(t *testing.T) {
+	a, b := EngineName("fake-fail-a"), EngineName("fake-fail-b")
+	RegisterTranslator(newFakeEngine(a, "", errors.New("boom"), 0))
+	RegisterTranslator(newFakeEngine(b, "", errors.New("boom"), 0))
+
+	p := NewPool(Quorum, 0, a, b)
+	_, err := p.Run(context.Background(), "en", "fr", "hi")
+	if err == nil {
+		t.Fatal("expected error when every engine fails")
+	}
+}
+
+func TestPoolFirstSuccessIgnoresErroredEngines(t *testing.T) {
+	slowOK, fastFail := EngineName("fake-slowok"), EngineName("fake-fastfail")
+	RegisterTranslator(newFakeEngine(fastFail, "", errors.New("boom"), 0))
+	RegisterTranslator(newFakeEngine(slowOK, "Bonjour", nil, 20*time.Millisecond))
+
+	p := NewPool(FirstSuccess, 0, fastFail, slowOK)
+	got, err := p.Run(context.Background(), "en", "fr", "hello")
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if got.(Result).String() != "Bonjour" {
+		t.Fatalf("got %v, want %q", got, "Bonjour")
+	}
+}
+
+func TestPoolAllReturnsMapKeyedByEngine(t *testing.T) {
+	ok, fail := EngineName("fake-all-ok"), EngineName("fake-all-fail")
+	RegisterTranslator(newFakeEngine(ok, "Hola", nil, 0))
+	RegisterTranslator(newFakeEngine(fail, "", errors.New("boom"), 0))
+
+	p := NewPool(All, 0, ok, fail)
+	got, err := p.Run(context.Background(), "en", "es", "hello")
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	results := got.(map[EngineName]Result)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1 (failed engines should be omitted): %v", len(results), results)
+	}
+	if results[ok].String() != "Hola" {
+		t.Fatalf("got %v, want %q", results[ok], "Hola")
+	}
+}
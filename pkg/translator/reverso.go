@@ -0,0 +1,83 @@
+package translator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+)
+
+const reversoAPIURL = "https://api.reverso.net/translate/v1/translation"
+
+type reversoTranslator struct {
+	*basicTranslator
+}
+
+// NewReverso creates a Translator backed by the Reverso API. opts can
+// attach middleware via WithMiddleware (see also Use for middleware shared
+// across all engines).
+func NewReverso(cache SessionCache, opts ...Option) Translator {
+	o := resolveOptions(opts...)
+	t := &reversoTranslator{
+		basicTranslator: &basicTranslator{
+			engine:     EngineReverso,
+			agent:      DefaultAgent,
+			cache:      cache,
+			middleware: o.middleware,
+		},
+	}
+	t.basicTranslator.Translator = t
+	return t
+}
+
+func (r *reversoTranslator) Session() (*Session, error) {
+	return &Session{}, nil
+}
+
+type reversoRequest struct {
+	Format  string   `json:"format"`
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Input   []string `json:"input"`
+	Options struct{} `json:"options"`
+}
+
+type reversoResponse struct {
+	Translation []string `json:"translation"`
+}
+
+func (r *reversoTranslator) translateRaw(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	sl, tl, err := r.keepLang(srcLang, targetLang, text)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(reversoRequest{
+		Format: "text",
+		From:   sl,
+		To:     tl,
+		Input:  []string{text},
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.post(ctx, reversoAPIURL, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := r.checkStatus(resp); err != nil {
+		return nil, err
+	}
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var rr reversoResponse
+	if err := json.Unmarshal(raw, &rr); err != nil {
+		return nil, err
+	}
+	if len(rr.Translation) == 0 {
+		return nil, errors.New("reverso: empty response")
+	}
+	return TextResult(rr.Translation[0]), nil
+}
@@ -0,0 +1,46 @@
+package translator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReversoTranslateRaw(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req reversoRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		if req.From != "en" || req.To != "fr" {
+			t.Errorf("from/to = %s/%s, want en/fr", req.From, req.To)
+		}
+		fmt.Fprint(w, `{"translation":["Bonjour"]}`)
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	rv := NewReverso(NewSessionCache(MemSessionStore{}))
+	r, err := rv.Translate("en", "fr", "hello")
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if r.String() != "Bonjour" {
+		t.Fatalf("got %q, want %q", r.String(), "Bonjour")
+	}
+}
+
+func TestReversoTranslateRawEmptyResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"translation":[]}`)
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	rv := NewReverso(NewSessionCache(MemSessionStore{}))
+	if _, err := rv.Translate("en", "fr", "hello"); err == nil {
+		t.Fatal("expected an error on an empty translation list")
+	}
+}
@@ -0,0 +1,86 @@
+package translator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fakeSessionTranslator struct {
+	*basicTranslator
+	session *Session
+}
+
+func (f *fakeSessionTranslator) Session() (*Session, error) {
+	return f.session, nil
+}
+
+func (f *fakeSessionTranslator) translateRaw(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	return nil, ErrNotImplemented
+}
+
+func newFakeSessionTranslator(name EngineName, session *Session) *fakeSessionTranslator {
+	t := &fakeSessionTranslator{
+		basicTranslator: &basicTranslator{engine: name, agent: DefaultAgent},
+		session:         session,
+	}
+	t.basicTranslator.Translator = t
+	return t
+}
+
+func TestSessionCacheRefreshesExpiredSession(t *testing.T) {
+	engine := EngineName("fake-ttl")
+	fresh := &Session{ExprAt: time.Now().Add(time.Hour).Unix()}
+	RegisterTranslator(newFakeSessionTranslator(engine, fresh))
+
+	cache := NewSessionCache(MemSessionStore{})
+	cache.memSession[engine] = &Session{ExprAt: time.Now().Add(-time.Hour).Unix()}
+
+	got, err := cache.GetSession(engine)
+	if err != nil {
+		t.Fatalf("GetSession returned error: %v", err)
+	}
+	if got != fresh {
+		t.Fatalf("got %v, want the refreshed session %v", got, fresh)
+	}
+	if cache.memSession[engine] != fresh {
+		t.Fatal("expired session was not replaced in the in-memory cache")
+	}
+}
+
+func TestSessionCacheKeepsUnexpiredSession(t *testing.T) {
+	engine := EngineName("fake-ttl-fresh")
+	cached := &Session{ExprAt: time.Now().Add(time.Hour).Unix()}
+	// If GetSession didn't honor the cached, unexpired session it would call
+	// Session() on this translator and return refreshed instead.
+	refreshed := &Session{ExprAt: time.Now().Add(2 * time.Hour).Unix()}
+	RegisterTranslator(newFakeSessionTranslator(engine, refreshed))
+
+	cache := NewSessionCache(MemSessionStore{})
+	cache.memSession[engine] = cached
+
+	got, err := cache.GetSession(engine)
+	if err != nil {
+		t.Fatalf("GetSession returned error: %v", err)
+	}
+	if got != cached {
+		t.Fatalf("got %v, want the still-valid cached session %v", got, cached)
+	}
+}
+
+func TestSessionCacheTreatsZeroExprAtAsNeverExpiring(t *testing.T) {
+	engine := EngineName("fake-ttl-noexpiry")
+	cached := &Session{}
+	RegisterTranslator(newFakeSessionTranslator(engine, &Session{ExprAt: time.Now().Add(time.Hour).Unix()}))
+
+	cache := NewSessionCache(MemSessionStore{})
+	cache.memSession[engine] = cached
+
+	got, err := cache.GetSession(engine)
+	if err != nil {
+		t.Fatalf("GetSession returned error: %v", err)
+	}
+	if got != cached {
+		t.Fatalf("got %v, want the cached session with no expiry (ExprAt == 0)", got)
+	}
+}
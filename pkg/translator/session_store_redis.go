@@ -0,0 +1,51 @@
+package translator
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisSessionStore persists sessions in Redis, keyed by engine, so a
+// fleet of translator processes can share sessions instead of each one
+// re-authenticating independently.
+type RedisSessionStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisSessionStore wraps an existing Redis client. prefix is prepended
+// to every key (e.g. "translax:session:") and may be empty.
+func NewRedisSessionStore(client *redis.Client, prefix string) *RedisSessionStore {
+	return &RedisSessionStore{client: client, prefix: prefix}
+}
+
+func (r *RedisSessionStore) key(engine EngineName) string {
+	return r.prefix + string(engine)
+}
+
+func (r *RedisSessionStore) Load(engine EngineName) (*Session, error) {
+	d, err := r.client.Get(context.Background(), r.key(engine)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	s := new(Session)
+	if err := yaml.Unmarshal(d, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *RedisSessionStore) Save(engine EngineName, session *Session) error {
+	d, err := yaml.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := r.client.Set(context.Background(), r.key(engine), d, 0).Err(); err != nil {
+		return fmt.Errorf("redis session store: %v", err)
+	}
+	return nil
+}
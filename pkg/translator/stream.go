@@ -0,0 +1,145 @@
+package translator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+)
+
+// chunkLimits holds each engine's approximate per-request character limit.
+// Engines not listed fall back to defaultChunkLimit.
+var chunkLimits = map[EngineName]int{
+	EngineGoogle:         5000,
+	EngineBing:           1000,
+	EngineYoudao:         5000,
+	EngineSougou:         5000,
+	EngineDeepL:          5000,
+	EngineLibreTranslate: 5000,
+	EngineReverso:        2000,
+	EngineWatson:         5000,
+	EngineHuoShan:        5000,
+}
+
+const defaultChunkLimit = 2000
+
+// streamWorkers bounds how many chunks are translated concurrently by
+// TranslateStream.
+const streamWorkers = 4
+
+// sentenceBoundaries are the runes TranslateStream prefers to split chunks
+// on, so words are not cut mid-way unless a chunk's span holds none of them.
+const sentenceBoundaries = ".!?。！？\n"
+
+// TranslateStream is the default, engine-agnostic implementation of
+// Translator.TranslateStream: it splits r into chunks no larger than the
+// engine's limit (breaking on sentence boundaries), translates chunks
+// concurrently through a bounded worker pool, and writes them to w in the
+// original order.
+func (b *basicTranslator) TranslateStream(ctx context.Context, srcLang, targetLang string, r io.Reader, w io.Writer) error {
+	if b.Translator == nil {
+		return ErrNotImplemented
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	limit := chunkLimits[b.Engine()]
+	if limit <= 0 {
+		limit = defaultChunkLimit
+	}
+	chunks := splitChunks(string(data), limit)
+	translated := make([]string, len(chunks))
+
+	sem := make(chan struct{}, streamWorkers)
+	var wg sync.WaitGroup
+	errs := make([]error, len(chunks))
+	for i, chunk := range chunks {
+		i, chunk := i, chunk
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res, err := b.Translator.TranslateCtx(ctx, srcLang, targetLang, chunk)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			translated[i] = res.String()
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	for _, t := range translated {
+		if _, err := io.WriteString(w, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitChunks breaks text into pieces no longer than limit runes, breaking
+// on sentence boundaries where possible. A single sentence longer than
+// limit is hard-cut as a last resort.
+func splitChunks(text string, limit int) []string {
+	if text == "" {
+		return nil
+	}
+	var chunks []string
+	runes := []rune(text)
+	start := 0
+	for start < len(runes) {
+		end := start + limit
+		if end >= len(runes) {
+			chunks = append(chunks, string(runes[start:]))
+			break
+		}
+		breakAt := lastSentenceBoundary(runes, start, end)
+		if breakAt <= start {
+			breakAt = end
+		}
+		chunks = append(chunks, string(runes[start:breakAt]))
+		start = breakAt
+	}
+	return chunks
+}
+
+// lastSentenceBoundary returns the index just after the last sentence
+// boundary rune in runes[start+1:end], or start if none is found.
+func lastSentenceBoundary(runes []rune, start, end int) int {
+	for i := end - 1; i > start; i-- {
+		if strings.ContainsRune(sentenceBoundaries, runes[i]) {
+			return i + 1
+		}
+	}
+	return start
+}
+
+// TransFile translates the contents of inPath and writes the result to
+// outPath using engine's TranslateStream.
+func TransFile(engine EngineName, from, to, inPath, outPath string) error {
+	t, ok := ENGINES[engine]
+	if !ok {
+		return fmt.Errorf("translator: engine %q not registered", engine)
+	}
+	in, err := os.Open(inPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return t.TranslateStream(context.Background(), from, to, in, out)
+}
@@ -0,0 +1,124 @@
+package translator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitChunksBreaksOnSentenceBoundary(t *testing.T) {
+	text := "Hello there. How are you? I am fine."
+	chunks := splitChunks(text, 15)
+	if got := strings.Join(chunks, ""); got != text {
+		t.Fatalf("chunks don't reassemble to the original text: got %q, want %q", got, text)
+	}
+	for _, c := range chunks {
+		if len([]rune(c)) > 15 {
+			t.Errorf("chunk %q exceeds the 15-rune limit", c)
+		}
+	}
+	want := []string{"Hello there.", " How are you?", " I am fine."}
+	if strings.Join(chunks, "|") != strings.Join(want, "|") {
+		t.Fatalf("got chunks %q, want %q split after each sentence", chunks, want)
+	}
+}
+
+func TestSplitChunksHardCutsOversizedSentence(t *testing.T) {
+	text := strings.Repeat("a", 40)
+	chunks := splitChunks(text, 10)
+	if got := strings.Join(chunks, ""); got != text {
+		t.Fatalf("chunks don't reassemble to the original text: got %q, want %q", got, text)
+	}
+	for _, c := range chunks {
+		if len([]rune(c)) > 10 {
+			t.Errorf("chunk %q exceeds the 10-rune hard limit", c)
+		}
+	}
+}
+
+func TestSplitChunksEmptyInput(t *testing.T) {
+	if chunks := splitChunks("", 10); chunks != nil {
+		t.Fatalf("got %v, want nil for empty input", chunks)
+	}
+}
+
+func TestSplitChunksCJKPunctuation(t *testing.T) {
+	text := "你好世界。再见。"
+	chunks := splitChunks(text, 5)
+	if got := strings.Join(chunks, ""); got != text {
+		t.Fatalf("chunks don't reassemble to the original text: got %q, want %q", got, text)
+	}
+}
+
+func TestLastSentenceBoundaryReturnsStartWhenNoneFound(t *testing.T) {
+	runes := []rune("abcdefgh")
+	if got := lastSentenceBoundary(runes, 0, 8); got != 0 {
+		t.Fatalf("got %d, want 0 when no boundary exists in range", got)
+	}
+}
+
+func TestLastSentenceBoundaryFindsLastOccurrence(t *testing.T) {
+	runes := []rune("a.b.cdef")
+	if got := lastSentenceBoundary(runes, 0, 8); got != 4 {
+		t.Fatalf("got %d, want 4 (just after the second '.')", got)
+	}
+}
+
+// echoFakeEngine translates by uppercasing the chunk, so TranslateStream
+// tests can verify both content and ordering survive the bounded,
+// concurrent worker pool.
+type echoFakeEngine struct {
+	*basicTranslator
+}
+
+func newEchoFakeEngine(name EngineName) *echoFakeEngine {
+	t := &echoFakeEngine{basicTranslator: &basicTranslator{engine: name, agent: DefaultAgent}}
+	t.basicTranslator.Translator = t
+	return t
+}
+
+func (e *echoFakeEngine) Session() (*Session, error) {
+	return &Session{}, nil
+}
+
+func (e *echoFakeEngine) translateRaw(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	// A small, varying delay to encourage chunks to finish out of order,
+	// exercising TranslateStream's in-order reassembly.
+	time.Sleep(time.Duration(len(text)%3) * time.Millisecond)
+	return TextResult(strings.ToUpper(text)), nil
+}
+
+func TestTranslateStreamPreservesOrder(t *testing.T) {
+	chunkLimits["fake-stream"] = 10
+	defer delete(chunkLimits, "fake-stream")
+
+	e := newEchoFakeEngine("fake-stream")
+	in := strings.NewReader("one two three four five six seven eight nine ten")
+	var out strings.Builder
+	if err := e.TranslateStream(context.Background(), "en", "fr", in, &out); err != nil {
+		t.Fatalf("TranslateStream returned error: %v", err)
+	}
+	want := strings.ToUpper("one two three four five six seven eight nine ten")
+	if out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestTranslateStreamPropagatesChunkError(t *testing.T) {
+	e := newEchoFakeEngine("fake-stream-err")
+	e.basicTranslator.Translator = &erroringFakeEngine{echoFakeEngine: e}
+	in := strings.NewReader("hello")
+	var out strings.Builder
+	if err := e.TranslateStream(context.Background(), "en", "fr", in, &out); err == nil {
+		t.Fatal("expected TranslateStream to propagate a chunk translation error")
+	}
+}
+
+type erroringFakeEngine struct {
+	*echoFakeEngine
+}
+
+func (e *erroringFakeEngine) translateRaw(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	return nil, ErrNotImplemented
+}
@@ -2,28 +2,47 @@ package translator
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
 
 var DefaultAgent = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/88.0.4324.146 Safari/537.36"
 
+// sharedHTTPClient is reused by every engine so that connections to the
+// same translation backend are pooled instead of dialed per request.
+var sharedHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+}
+
 type EngineName string
 
 const (
-	EngineBaidu  = EngineName("baidu")
-	EngineSougou = EngineName("sougou")
-	EngineYoudao = EngineName("youdao")
-	EngineBing   = EngineName("bing")
-	EngineGoogle = EngineName("google")
+	EngineBaidu          = EngineName("baidu")
+	EngineSougou         = EngineName("sougou")
+	EngineYoudao         = EngineName("youdao")
+	EngineBing           = EngineName("bing")
+	EngineGoogle         = EngineName("google")
+	EngineDeepL          = EngineName("deepl")
+	EngineLibreTranslate = EngineName("libretranslate")
+	EngineReverso        = EngineName("reverso")
+	EngineWatson         = EngineName("watson")
+	EngineHuoShan        = EngineName("huoshan")
 )
 
 var LangMap = map[string]string{
@@ -118,38 +137,114 @@ type Result interface {
 	fmt.Stringer
 }
 
+// TextResult is the plain-string Result returned by engines that only do
+// sentence/paragraph level translation.
+type TextResult string
+
+func (t TextResult) String() string {
+	return string(t)
+}
+
+// Explanation is a single word-level sense returned by a bilingual
+// dictionary lookup: its part of speech, the translated sense, and an
+// optional usage example.
+type Explanation struct {
+	POS         string
+	Translation string
+	Example     string
+}
+
+// DictResult is the richer Result returned by engines that can tell a
+// word-level dictionary lookup from a sentence-level translation. It still
+// satisfies Result/fmt.Stringer so it can be used anywhere a plain
+// translated string is expected; String() returns the top translation.
+type DictResult struct {
+	Text    string
+	Details []Explanation
+}
+
+func (d *DictResult) String() string {
+	if len(d.Details) > 0 {
+		return d.Details[0].Translation
+	}
+	return d.Text
+}
+
+// DictTranslator is a sibling to Translator for engines that support
+// dictionary-style lookups in addition to plain translation. Implementing
+// it is optional; engines that don't support it simply don't satisfy it.
+type DictTranslator interface {
+	Translator
+	Dict(srcLang, targetLang, word string) (*DictResult, error)
+}
+
+// Credential holds non-cookie auth material (API keys, service URLs, ...)
+// for engines that authenticate over a simple header/token rather than a
+// browser-style cookie jar.
+type Credential struct {
+	APIKey string `yaml:"api_key,omitempty"`
+	URL    string `yaml:"url,omitempty"`
+}
+
 type Session struct {
-	ExprAt  int64          `yaml:"expr_at"`
-	Cookies []*http.Cookie `yaml:"cookies"`
+	ExprAt     int64          `yaml:"expr_at"`
+	Cookies    []*http.Cookie `yaml:"cookies"`
+	Credential *Credential    `yaml:"credential,omitempty"`
 }
 
 type SessionCache interface {
 	Persist(engine EngineName, session *Session) error
 	GetSession(engine EngineName) (*Session, error)
 	GetTranslatorByEngineName(engine EngineName) Translator
+	// LoadPersisted reads engine's session directly from the underlying
+	// SessionStore, bypassing the in-memory cache and ENGINES lookup.
+	// Constructors use it to hydrate credentials a previous process run
+	// persisted, before the engine itself has been registered.
+	LoadPersisted(engine EngineName) (*Session, error)
 }
 
-type defaultSessionCache struct {
-	SessionCache
-	memSession map[EngineName]*Session
+// SessionStore is where a SessionCache durably keeps sessions. Swapping the
+// store lets a long-running process share sessions across restarts
+// (FileSessionStore), across multiple processes (a Redis-backed store), or
+// opt out of persistence entirely (MemSessionStore).
+type SessionStore interface {
+	Load(engine EngineName) (*Session, error)
+	Save(engine EngineName, session *Session) error
 }
 
-func (c *defaultSessionCache) Load() error {
+// FileSessionStore persists sessions as one YAML file per engine under
+// ~/.config/translaX. This is the store translaX has always used.
+type FileSessionStore struct{}
+
+func (FileSessionStore) Load(engine EngineName) (*Session, error) {
+	dir, err := getDir()
+	if err != nil {
+		return nil, err
+	}
+	d, err := ioutil.ReadFile(filepath.Join(dir, string(engine)+".yaml"))
+	if err != nil {
+		return nil, err
+	}
+	s := new(Session)
+	if err := yaml.Unmarshal(d, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (FileSessionStore) Save(engine EngineName, session *Session) error {
 	dir, err := getDir()
 	if err != nil {
 		return err
 	}
-	for e := range ENGINES {
-		d, err := ioutil.ReadFile(filepath.Join(dir, string(e)+".yaml"))
-		if err != nil {
-			return err
-		}
-		s := new(Session)
-		if err := yaml.Unmarshal(d, s); err == nil {
-			c.memSession[e] = s
-		}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("缓存目录创建失败: %v", err)
 	}
-	return nil
+	d, err := yaml.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, string(engine)+".yaml"), d, os.ModePerm)
 }
 
 func getDir() (dir string, err error) {
@@ -160,64 +255,214 @@ func getDir() (dir string, err error) {
 	dir = filepath.Join(homeDir, ".config", "translaX")
 	return
 }
+
+// MemSessionStore never persists; Load always misses so the owning cache
+// always refreshes sessions from the translator itself.
+type MemSessionStore struct{}
+
+func (MemSessionStore) Load(engine EngineName) (*Session, error) {
+	return nil, os.ErrNotExist
+}
+
+func (MemSessionStore) Save(engine EngineName, session *Session) error {
+	return nil
+}
+
+type defaultSessionCache struct {
+	SessionCache
+	mu         sync.RWMutex
+	memSession map[EngineName]*Session
+	store      SessionStore
+}
+
+// NewSessionCache creates a SessionCache backed by store. The returned
+// cache keeps an in-memory copy on top of store and honors Session.ExprAt,
+// refreshing expired sessions via the owning Translator. Load populates the
+// in-memory copy from store for every registered engine, tolerating
+// engines that have no saved session yet.
+func NewSessionCache(store SessionStore) *defaultSessionCache {
+	return &defaultSessionCache{
+		memSession: make(map[EngineName]*Session),
+		store:      store,
+	}
+}
+
+func (c *defaultSessionCache) Load() error {
+	for e := range ENGINES {
+		s, err := c.store.Load(e)
+		if err != nil {
+			// Tolerate engines that have never been used yet.
+			continue
+		}
+		c.mu.Lock()
+		c.memSession[e] = s
+		c.mu.Unlock()
+	}
+	return nil
+}
+
 func (c *defaultSessionCache) Persist(engine EngineName, session *Session) error {
 	if session == nil {
 		return errors.New("session can not be nil.")
 	}
-	dir, err := getDir()
-	if err != nil {
-		return err
-	}
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return fmt.Errorf("缓存目录创建失败: %v", err)
-	}
-	if d, err := yaml.Marshal(session); err != nil {
-		return err
-	} else {
-		return ioutil.WriteFile(filepath.Join(dir, string(engine)+".yaml"), d, os.ModePerm)
-	}
+	return c.store.Save(engine, session)
+}
+
+func sessionExpired(s *Session) bool {
+	return s.ExprAt > 0 && s.ExprAt <= time.Now().Unix()
 }
 
 func (c *defaultSessionCache) GetSession(engine EngineName) (*Session, error) {
-	if s, ok := c.memSession[engine]; ok {
+	c.mu.RLock()
+	s, ok := c.memSession[engine]
+	c.mu.RUnlock()
+	if ok && !sessionExpired(s) {
 		return s, nil
-	} else {
-		t := c.GetTranslatorByEngineName(engine)
-		if t == nil {
-			return nil, errors.New("no translator found")
-		}
-		s, err := t.Session()
-		if err == nil {
-			c.memSession[engine] = s
-			c.Persist(engine, s)
-		}
-		return s, err
 	}
+
+	t := c.GetTranslatorByEngineName(engine)
+	if t == nil {
+		return nil, errors.New("no translator found")
+	}
+	s, err := t.Session()
+	if err == nil {
+		c.mu.Lock()
+		c.memSession[engine] = s
+		c.mu.Unlock()
+		c.Persist(engine, s)
+	}
+	return s, err
 }
 
 func (c *defaultSessionCache) GetTranslatorByEngineName(engine EngineName) Translator {
 	return ENGINES[engine]
 }
 
+func (c *defaultSessionCache) LoadPersisted(engine EngineName) (*Session, error) {
+	return c.store.Load(engine)
+}
+
+// ErrNotImplemented is returned by Translator methods that are optional and
+// not supported by a given engine, such as Detect.
+var ErrNotImplemented = errors.New("not implemented")
+
 type Translator interface {
 	Engine() EngineName
 	Session() (*Session, error)
 	Translate(srcLang, targetLang, text string) (Result, error)
-	postForm(url string, data url.Values) (*http.Response, error)
-	post(url string, data []byte) (*http.Response, error)
+	// TranslateCtx is Translate with cancellation/timeout support, used by
+	// Pool to fan a single request out across engines. Translate is
+	// equivalent to TranslateCtx(context.Background(), ...).
+	TranslateCtx(ctx context.Context, srcLang, targetLang, text string) (Result, error)
+	// translateRaw is the engine's actual implementation, called at the
+	// end of the middleware chain built by TranslateCtx.
+	translateRaw(ctx context.Context, srcLang, targetLang, text string) (Result, error)
+	// Detect guesses the language of text, for engines that support it.
+	// Engines without detection support return ErrNotImplemented.
+	Detect(text string) (lang string, confidence float64, err error)
+	// TranslateStream translates arbitrarily large input read from r,
+	// writing the translated chunks to w in their original order once every
+	// chunk has been translated; nothing is written if any chunk fails.
+	TranslateStream(ctx context.Context, srcLang, targetLang string, r io.Reader, w io.Writer) error
+	postForm(ctx context.Context, url string, data url.Values) (*http.Response, error)
+	post(ctx context.Context, url string, data []byte) (*http.Response, error)
+}
+
+// TranslateFunc is the shape of a translation call as it passes through a
+// middleware chain.
+type TranslateFunc func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error)
+
+// Middleware wraps a TranslateFunc to add cross-cutting behavior (caching,
+// rate limiting, retries, logging, ...) around it.
+type Middleware func(next TranslateFunc) TranslateFunc
+
+// Chain composes middlewares into one, applying them in the order given:
+// Chain(a, b)(next) behaves like a(b(next)).
+func Chain(mws ...Middleware) Middleware {
+	return func(next TranslateFunc) TranslateFunc {
+		for i := len(mws) - 1; i >= 0; i-- {
+			next = mws[i](next)
+		}
+		return next
+	}
+}
+
+var (
+	globalMiddlewareMu sync.RWMutex
+	globalMiddleware   []Middleware
+)
+
+// Use registers a middleware that applies to every engine, in addition to
+// any engine registered its own via WithMiddleware.
+func Use(mw Middleware) {
+	globalMiddlewareMu.Lock()
+	defer globalMiddlewareMu.Unlock()
+	globalMiddleware = append(globalMiddleware, mw)
+}
+
+func snapshotGlobalMiddleware() []Middleware {
+	globalMiddlewareMu.RLock()
+	defer globalMiddlewareMu.RUnlock()
+	mws := make([]Middleware, len(globalMiddleware))
+	copy(mws, globalMiddleware)
+	return mws
+}
+
+// StatusError reports a non-2xx HTTP response from an engine backend, so
+// middleware such as a retrier can decide whether it's worth retrying.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected http status %d", e.StatusCode)
+}
+
+// checkStatus returns a *StatusError if resp did not succeed.
+func (b *basicTranslator) checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 400 {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+	return nil
 }
 
 type basicTranslator struct {
 	Translator
-	engine EngineName
-	agent  string
-	cache  SessionCache
+	engine     EngineName
+	agent      string
+	cache      SessionCache
+	middleware []Middleware
 }
 
 func (b *basicTranslator) Engine() EngineName {
 	return b.engine
 }
 
+// Translate is Translate with no deadline; see TranslateCtx.
+func (b *basicTranslator) Translate(srcLang, targetLang, text string) (Result, error) {
+	return b.TranslateCtx(context.Background(), srcLang, targetLang, text)
+}
+
+// TranslateCtx runs the engine's translateRaw through the global and
+// per-engine middleware chain.
+func (b *basicTranslator) TranslateCtx(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	if b.Translator == nil {
+		return nil, ErrNotImplemented
+	}
+	raw := func(ctx context.Context, engine EngineName, srcLang, targetLang, text string) (Result, error) {
+		return b.Translator.translateRaw(ctx, srcLang, targetLang, text)
+	}
+	mws := append(snapshotGlobalMiddleware(), b.middleware...)
+	fn := Chain(mws...)(raw)
+	return fn(ctx, b.Engine(), srcLang, targetLang, text)
+}
+
+// Detect is the default, unsupported implementation of language detection.
+// Engines that can detect a source language override it.
+func (b *basicTranslator) Detect(text string) (lang string, confidence float64, err error) {
+	return "", 0, ErrNotImplemented
+}
+
 func (b *basicTranslator) addHeaders(req *http.Request) {
 	req.Header.Set("User-Agent", b.agent)
 	// Headers for Youdao
@@ -232,9 +477,9 @@ func (b *basicTranslator) addHeaders(req *http.Request) {
 	}
 }
 
-func (b *basicTranslator) postForm(url string, data url.Values) (resp *http.Response, err error) {
+func (b *basicTranslator) postForm(ctx context.Context, url string, data url.Values) (resp *http.Response, err error) {
 	var req *http.Request
-	if req, err = http.NewRequest("POST", url, strings.NewReader(data.Encode())); err != nil {
+	if req, err = http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(data.Encode())); err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=UTF-8;")
@@ -246,12 +491,12 @@ func (b *basicTranslator) postForm(url string, data url.Values) (resp *http.Resp
 		req.Header.Add("Cookie", c.Raw)
 	}
 	b.addHeaders(req)
-	return http.DefaultClient.Do(req)
+	return sharedHTTPClient.Do(req)
 }
 
-func (b *basicTranslator) post(url string, data []byte) (resp *http.Response, err error) {
+func (b *basicTranslator) post(ctx context.Context, url string, data []byte) (resp *http.Response, err error) {
 	var req *http.Request
-	if req, err = http.NewRequest("POST", url, bytes.NewBuffer(data)); err != nil {
+	if req, err = http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(data)); err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
@@ -263,19 +508,29 @@ func (b *basicTranslator) post(url string, data []byte) (resp *http.Response, er
 		req.Header.Add("Cookie", c.Raw)
 	}
 	req.Header.Set("User-Agent", b.agent)
-	return http.DefaultClient.Do(req)
+	return sharedHTTPClient.Do(req)
 }
 
-func (b *basicTranslator) get(url string) (resp *http.Response, err error) {
+func (b *basicTranslator) get(ctx context.Context, url string) (resp *http.Response, err error) {
 	var req *http.Request
-	if req, err = http.NewRequest("GET", url, nil); err != nil {
+	if req, err = http.NewRequestWithContext(ctx, "GET", url, nil); err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", b.agent)
-	return http.DefaultClient.Do(req)
+	return sharedHTTPClient.Do(req)
 }
 
-func (b *basicTranslator) keepLang(srcLang, targetLang string) (sl, tl string, err error) {
+func (b *basicTranslator) keepLang(srcLang, targetLang, text string) (sl, tl string, err error) {
+	if srcLang == "" || strings.ToLower(srcLang) == "auto" {
+		if b.Translator == nil {
+			return "", "", ErrNotImplemented
+		}
+		detected, _, derr := b.Translator.Detect(text)
+		if derr != nil {
+			return "", "", derr
+		}
+		srcLang = detected
+	}
 	if sl, okSl := LangMap[strings.ToLower(srcLang)]; okSl {
 		if tl, okTl := LangMap[strings.ToLower(targetLang)]; okTl {
 			if b.Engine() == EngineBing {
@@ -306,15 +561,27 @@ func RegisterTranslator(translator Translator) {
 	ENGINES[translator.Engine()] = translator
 }
 
-var defaultCache = &defaultSessionCache{
-	memSession: make(map[EngineName]*Session),
-}
+var defaultCache = NewSessionCache(FileSessionStore{})
 
+// NewSougou, NewBing, NewGoogle, and NewYoudao are not implemented in this
+// source tree (no sougou.go/bing.go/google.go/youdao.go exist here; see the
+// baseline commit). None of the four override Detect, so calling Trans or
+// keepLang with an empty/"auto" srcLang still returns ErrNotImplemented for
+// sougou/bing/google/youdao today (baidu is not registered, and Trans
+// rejects it as an unselected engine). DeepL is the only engine with a
+// real Detect (see deepl.go) because it's the only one whose source is
+// actually present to wire auto-detect into; extending auto-detect to the
+// other four is out of scope until their implementations land in this tree.
 func init() {
 	RegisterTranslator(NewSougou(defaultCache))
 	RegisterTranslator(NewBing(defaultCache))
 	RegisterTranslator(NewGoogle(defaultCache))
 	RegisterTranslator(NewYoudao(defaultCache))
+	RegisterTranslator(NewDeepL(defaultCache, ""))
+	RegisterTranslator(NewLibreTranslate(defaultCache))
+	RegisterTranslator(NewReverso(defaultCache))
+	RegisterTranslator(NewWatson(defaultCache, "", ""))
+	RegisterTranslator(NewHuoShan(defaultCache))
 	// after register all translator
 	defaultCache.Load()
 }
@@ -333,7 +600,33 @@ func Trans(engine EngineName, from, to, text string) (string, error) {
 	case EngineYoudao:
 		r, err := ENGINES[EngineYoudao].Translate(from, to, text)
 		return fmt.Sprintf("%v", r), err
+	case EngineDeepL:
+		r, err := ENGINES[EngineDeepL].Translate(from, to, text)
+		return fmt.Sprintf("%v", r), err
+	case EngineLibreTranslate:
+		r, err := ENGINES[EngineLibreTranslate].Translate(from, to, text)
+		return fmt.Sprintf("%v", r), err
+	case EngineReverso:
+		r, err := ENGINES[EngineReverso].Translate(from, to, text)
+		return fmt.Sprintf("%v", r), err
+	case EngineWatson:
+		r, err := ENGINES[EngineWatson].Translate(from, to, text)
+		return fmt.Sprintf("%v", r), err
+	case EngineHuoShan:
+		r, err := ENGINES[EngineHuoShan].Translate(from, to, text)
+		return fmt.Sprintf("%v", r), err
 	default:
 		return "", errors.New("engine not selected.")
 	}
 }
+
+// Detect guesses the source language of text using the given engine. It
+// mirrors Trans and returns ErrNotImplemented for engines without
+// detection support.
+func Detect(engine EngineName, text string) (string, float64, error) {
+	t, ok := ENGINES[engine]
+	if !ok {
+		return "", 0, errors.New("engine not selected.")
+	}
+	return t.Detect(text)
+}
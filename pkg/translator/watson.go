@@ -0,0 +1,103 @@
+package translator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+)
+
+type watsonTranslator struct {
+	*basicTranslator
+	apiKey string
+	url    string
+}
+
+// NewWatson creates a Translator backed by IBM Watson Language Translator.
+// apiKey and serviceURL are both issued per-instance by IBM Cloud, so
+// unlike the other commercial engines there is no shared public endpoint.
+// Either may be left empty to hydrate from what a previous run persisted
+// to the session cache (see Session/Credential).
+// opts can attach middleware via WithMiddleware (see also Use for
+// middleware shared across all engines).
+func NewWatson(cache SessionCache, apiKey, serviceURL string, opts ...Option) Translator {
+	o := resolveOptions(opts...)
+	apiKey, serviceURL = hydrateCredential(cache, EngineWatson, apiKey, serviceURL)
+	t := &watsonTranslator{
+		basicTranslator: &basicTranslator{
+			engine:     EngineWatson,
+			agent:      DefaultAgent,
+			cache:      cache,
+			middleware: o.middleware,
+		},
+		apiKey: apiKey,
+		url:    serviceURL,
+	}
+	t.basicTranslator.Translator = t
+	return t
+}
+
+func (w *watsonTranslator) Session() (*Session, error) {
+	return &Session{Credential: &Credential{APIKey: w.apiKey, URL: w.url}}, nil
+}
+
+type watsonRequest struct {
+	Text  []string `json:"text"`
+	Model string   `json:"model_id"`
+}
+
+type watsonResponse struct {
+	Translations []struct {
+		Translation string `json:"translation"`
+	} `json:"translations"`
+}
+
+// translateRaw authenticates with HTTP Basic Auth using the IBM Cloud
+// convention of a literal "apikey" username and the instance's API key as
+// the password, so it builds the request directly instead of going through
+// basicTranslator.post.
+func (w *watsonTranslator) translateRaw(ctx context.Context, srcLang, targetLang, text string) (Result, error) {
+	if w.apiKey == "" || w.url == "" {
+		return nil, errors.New("watson: api key and service url are required")
+	}
+	sl, tl, err := w.keepLang(srcLang, targetLang, text)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(watsonRequest{
+		Text:  []string{text},
+		Model: sl + "-" + tl,
+	})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := w.url + "/v3/translate?version=2018-05-01"
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth("apikey", w.apiKey)
+	resp, err := sharedHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := w.checkStatus(resp); err != nil {
+		return nil, err
+	}
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var wr watsonResponse
+	if err := json.Unmarshal(raw, &wr); err != nil {
+		return nil, err
+	}
+	if len(wr.Translations) == 0 {
+		return nil, errors.New("watson: empty response")
+	}
+	return TextResult(wr.Translations[0].Translation), nil
+}
@@ -0,0 +1,39 @@
+package translator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWatsonTranslateRawSendsBasicAuth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok {
+			t.Fatal("request had no Basic Auth credentials")
+		}
+		if user != "apikey" || pass != "test-key" {
+			t.Errorf("got user/pass %q/%q, want %q/%q", user, pass, "apikey", "test-key")
+		}
+		fmt.Fprint(w, `{"translations":[{"translation":"Bonjour"}]}`)
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	w := NewWatson(NewSessionCache(MemSessionStore{}), "test-key", ts.URL)
+	r, err := w.Translate("en", "fr", "hello")
+	if err != nil {
+		t.Fatalf("Translate returned error: %v", err)
+	}
+	if r.String() != "Bonjour" {
+		t.Fatalf("got %q, want %q", r.String(), "Bonjour")
+	}
+}
+
+func TestWatsonTranslateRawRequiresAPIKeyAndURL(t *testing.T) {
+	w := NewWatson(NewSessionCache(MemSessionStore{}), "", "")
+	if _, err := w.Translate("en", "fr", "hello"); err == nil {
+		t.Fatal("expected an error when api key and service url are both empty")
+	}
+}